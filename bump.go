@@ -0,0 +1,97 @@
+package semver
+
+// IncMajor returns a copy of v with the major version incremented and
+// the minor, patch, prerelease and build components all cleared.
+func (v Version) IncMajor() Version {
+	return Version{Major: v.Major + 1}
+}
+
+// IncMinor returns a copy of v with the minor version incremented and
+// the patch, prerelease and build components all cleared.
+func (v Version) IncMinor() Version {
+	return Version{Major: v.Major, Minor: v.Minor + 1}
+}
+
+// IncPatch returns a copy of v with the patch version incremented and
+// the prerelease and build components cleared.
+func (v Version) IncPatch() Version {
+	return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1}
+}
+
+// WithPrerelease returns a copy of v with its prerelease component
+// replaced by pre (a dot-separated string such as "beta.2"), and its
+// build component cleared. An empty pre clears the prerelease component.
+func (v Version) WithPrerelease(pre string) (Version, error) {
+	v.Build = nil
+	if pre == "" {
+		v.Prerelease = nil
+		return v, nil
+	}
+	ids, err := parsePrerelease(pre)
+	if err != nil {
+		return Version{}, err
+	}
+	v.Prerelease = ids
+	return v, nil
+}
+
+// WithBuild returns a copy of v with its build metadata component
+// replaced by build (a dot-separated string such as "build.5"). An empty
+// build clears the build component.
+func (v Version) WithBuild(build string) (Version, error) {
+	if build == "" {
+		v.Build = nil
+		return v, nil
+	}
+	parts, err := parseBuild(build)
+	if err != nil {
+		return Version{}, err
+	}
+	v.Build = parts
+	return v, nil
+}
+
+// A ChangeKind describes the significance of a change relative to some
+// base version, for use with SuggestNext.
+type ChangeKind int
+
+const (
+	// ChangeNone indicates no observable change.
+	ChangeNone ChangeKind = iota
+	// ChangePatch indicates a backwards-compatible bug fix.
+	ChangePatch
+	// ChangeMinor indicates a backwards-compatible feature addition.
+	ChangeMinor
+	// ChangeMajor indicates a breaking change.
+	ChangeMajor
+)
+
+// SuggestNext suggests the next version after base given a change of the
+// specified kind, mirroring the convention used by Go module release
+// tooling: pre-1.0.0 modules are considered unstable, so a breaking
+// change only bumps the minor version and a compatible change only bumps
+// the patch version, while post-1.0.0 modules bump the corresponding
+// component and reset the lower ones.
+func SuggestNext(base Version, change ChangeKind) Version {
+	if base.Major == 0 {
+		switch change {
+		case ChangeMajor:
+			return base.IncMinor()
+		case ChangeMinor, ChangePatch:
+			return base.IncPatch()
+		default:
+			return base
+		}
+	}
+
+	switch change {
+	case ChangeMajor:
+		return base.IncMajor()
+	case ChangeMinor:
+		return base.IncMinor()
+	case ChangePatch:
+		return base.IncPatch()
+	default:
+		return base
+	}
+}
@@ -0,0 +1,96 @@
+package semver
+
+import "testing"
+
+func TestIncMajorMinorPatch(t *testing.T) {
+	v, err := Parse("v1.2.3-beta+build.5")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		got  Version
+		want string
+	}{
+		{"IncMajor", v.IncMajor(), "v2.0.0"},
+		{"IncMinor", v.IncMinor(), "v1.3.0"},
+		{"IncPatch", v.IncPatch(), "v1.2.4"},
+	}
+
+	for _, tc := range tests {
+		if got := tc.got.String(); got != tc.want {
+			t.Errorf("%s() = %s, want %s", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestWithPrerelease(t *testing.T) {
+	v := ver(1, 2, 3, "")
+
+	got, err := v.WithPrerelease("rc.1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "v1.2.3-rc.1"; got.String() != want {
+		t.Errorf("WithPrerelease(\"rc.1\") = %s, want %s", got, want)
+	}
+
+	cleared, err := got.WithPrerelease("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "v1.2.3"; cleared.String() != want {
+		t.Errorf("WithPrerelease(\"\") = %s, want %s", cleared, want)
+	}
+
+	if _, err := v.WithPrerelease("01"); err == nil {
+		t.Errorf("expected an error for an illegal prerelease identifier")
+	}
+}
+
+func TestWithBuild(t *testing.T) {
+	v := ver(1, 2, 3, "rc.1")
+
+	got, err := v.WithBuild("build.5")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "v1.2.3-rc.1+build.5"; got.String() != want {
+		t.Errorf("WithBuild(\"build.5\") = %s, want %s", got, want)
+	}
+
+	if _, err := v.WithBuild("build_5"); err == nil {
+		t.Errorf("expected an error for an illegal build identifier")
+	}
+}
+
+func TestSuggestNext(t *testing.T) {
+	tests := []struct {
+		base   string
+		change ChangeKind
+		want   string
+	}{
+		// pre-1.0.0: breaking changes bump minor, compatible bump patch
+		{"v0.1.0", ChangeMajor, "v0.2.0"},
+		{"v0.1.0", ChangeMinor, "v0.1.1"},
+		{"v0.1.0", ChangePatch, "v0.1.1"},
+		{"v0.1.0", ChangeNone, "v0.1.0"},
+
+		// post-1.0.0: standard semver bumps
+		{"v1.2.3", ChangeMajor, "v2.0.0"},
+		{"v1.2.3", ChangeMinor, "v1.3.0"},
+		{"v1.2.3", ChangePatch, "v1.2.4"},
+		{"v1.2.3", ChangeNone, "v1.2.3"},
+	}
+
+	for _, tc := range tests {
+		base, err := Parse(tc.base)
+		if err != nil {
+			t.Fatalf("unexpected error parsing %s: %v", tc.base, err)
+		}
+		if got := SuggestNext(base, tc.change).String(); got != tc.want {
+			t.Errorf("SuggestNext(%s, %v) = %s, want %s", tc.base, tc.change, got, tc.want)
+		}
+	}
+}
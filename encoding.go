@@ -0,0 +1,62 @@
+package semver
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// MarshalJSON implements json.Marshaler, encoding v as its canonical
+// string representation.
+func (v Version) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + v.String() + `"`), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, decoding v from its
+// canonical string representation.
+func (v *Version) UnmarshalJSON(data []byte) error {
+	// A JSON string is just its contents surrounded by quotes; strip
+	// them before handing off to the same parsing UnmarshalText uses.
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		return fmt.Errorf("semver: %q is not a JSON string", data)
+	}
+	return v.UnmarshalText(data[1 : len(data)-1])
+}
+
+// MarshalText implements encoding.TextMarshaler, encoding v as its
+// canonical string representation.
+func (v Version) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, decoding v from its
+// canonical string representation.
+func (v *Version) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
+// Scan implements database/sql.Scanner, so a Version can be read directly
+// from a TEXT or VARCHAR column. A nil src leaves v zeroed.
+func (v *Version) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case nil:
+		*v = Version{}
+		return nil
+	case string:
+		return v.UnmarshalText([]byte(s))
+	case []byte:
+		return v.UnmarshalText(s)
+	default:
+		return fmt.Errorf("semver: cannot scan %T into Version", src)
+	}
+}
+
+// Value implements database/sql/driver.Valuer, storing v as its canonical
+// string representation.
+func (v Version) Value() (driver.Value, error) {
+	return v.String(), nil
+}
@@ -0,0 +1,131 @@
+package semver
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+)
+
+type configDoc struct {
+	Name    string  `json:"name"`
+	Version Version `json:"version"`
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	in := configDoc{Name: "widget", Version: ver(1, 2, 3, "beta.2")}
+
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("unexpected error marshalling: %v", err)
+	}
+
+	var out configDoc
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("unexpected error unmarshalling: %v", err)
+	}
+
+	if !out.Version.Equals(in.Version) {
+		t.Errorf("round-tripped version %v, want %v", out.Version, in.Version)
+	}
+	if out.Name != in.Name {
+		t.Errorf("round-tripped name %q, want %q", out.Name, in.Name)
+	}
+}
+
+func TestUnmarshalJSONInvalid(t *testing.T) {
+	var v Version
+	if err := json.Unmarshal([]byte(`123`), &v); err == nil {
+		t.Errorf("expected an error unmarshalling a non-string JSON value")
+	}
+	if err := json.Unmarshal([]byte(`"not-a-version"`), &v); err == nil {
+		t.Errorf("expected an error unmarshalling an illegal version string")
+	}
+}
+
+func TestScanValue(t *testing.T) {
+	want := ver(2, 0, 0, "rc.1")
+
+	val, err := want.Value()
+	if err != nil {
+		t.Fatalf("unexpected error from Value: %v", err)
+	}
+
+	tests := []interface{}{
+		val,
+		[]byte(val.(string)),
+	}
+	for _, src := range tests {
+		var got Version
+		if err := got.Scan(src); err != nil {
+			t.Fatalf("unexpected error from Scan(%T): %v", src, err)
+		}
+		if !got.Equals(want) {
+			t.Errorf("Scan(%T) = %v, want %v", src, got, want)
+		}
+	}
+}
+
+func TestScanNil(t *testing.T) {
+	got := ver(1, 0, 0, "")
+	if err := got.Scan(nil); err != nil {
+		t.Fatalf("unexpected error from Scan(nil): %v", err)
+	}
+	if !got.Equals(Version{}) {
+		t.Errorf("Scan(nil) left %v, want the zero Version", got)
+	}
+}
+
+func TestScanInvalidType(t *testing.T) {
+	var v Version
+	if err := v.Scan(42); err == nil {
+		t.Errorf("expected an error scanning an int")
+	}
+}
+
+// nullVersion mirrors the sql.NullString pattern for an optional column.
+type nullVersion struct {
+	Version Version
+	Valid   bool
+}
+
+func (n *nullVersion) Scan(src interface{}) error {
+	if src == nil {
+		n.Version, n.Valid = Version{}, false
+		return nil
+	}
+	n.Valid = true
+	return n.Version.Scan(src)
+}
+
+func (n nullVersion) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Version.Value()
+}
+
+func TestNullVersionRoundTrip(t *testing.T) {
+	want := ver(1, 4, 0, "")
+	n := nullVersion{Version: want, Valid: true}
+
+	val, err := n.Value()
+	if err != nil {
+		t.Fatalf("unexpected error from Value: %v", err)
+	}
+
+	var got nullVersion
+	if err := got.Scan(val); err != nil {
+		t.Fatalf("unexpected error from Scan: %v", err)
+	}
+	if !got.Valid || !got.Version.Equals(want) {
+		t.Errorf("round-tripped %+v, want %+v", got, n)
+	}
+
+	var empty nullVersion
+	if err := empty.Scan(nil); err != nil {
+		t.Fatalf("unexpected error from Scan(nil): %v", err)
+	}
+	if empty.Valid {
+		t.Errorf("expected Valid to be false after scanning nil")
+	}
+}
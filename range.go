@@ -0,0 +1,305 @@
+package semver
+
+import (
+	"errors"
+	"strings"
+)
+
+// A Range is a predicate that reports whether a Version satisfies some
+// version constraint expression.
+type Range func(Version) bool
+
+var (
+	EmptyRange   = errors.New("Empty range string")
+	IllegalRange = errors.New("Illegal range string")
+)
+
+// ParseRange parses a version constraint expression into a Range.
+//
+// The grammar accepts comparators =, !=, <, <=, >, >= followed by a
+// (possibly partial) version, space-separated to mean conjunction
+// (">=1.2.0 <2.0.0"), "||" to mean disjunction, and the shorthand forms
+// "~1.2.3" (patch-level changes only), "^1.2.3" (minor+patch changes,
+// or patch-only changes below major version 1) and wildcard/X-ranges
+// such as "1.2.x", "1.x" and "*".
+//
+// A prerelease version only satisfies a Range if one of the comparators
+// in the same conjunction names a prerelease at the same
+// [major, minor, patch].
+func ParseRange(s string) (Range, error) {
+	s = strings.TrimSpace(s)
+	if len(s) == 0 {
+		return nil, EmptyRange
+	}
+
+	var orRanges []Range
+	for _, orPart := range strings.Split(s, "||") {
+		orPart = strings.TrimSpace(orPart)
+		if len(orPart) == 0 {
+			return nil, IllegalRange
+		}
+
+		var comps []comparator
+		for _, field := range strings.Fields(orPart) {
+			fieldComps, err := expandField(field)
+			if err != nil {
+				return nil, err
+			}
+			comps = append(comps, fieldComps...)
+		}
+
+		orRanges = append(orRanges, buildGroup(comps))
+	}
+
+	return orAll(orRanges), nil
+}
+
+// MustParseRange is like ParseRange but panics if s cannot be parsed.
+func MustParseRange(s string) Range {
+	r, err := ParseRange(s)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// AND returns a Range satisfied only when both r and o are satisfied.
+func (r Range) AND(o Range) Range {
+	return func(v Version) bool {
+		return r(v) && o(v)
+	}
+}
+
+// OR returns a Range satisfied when either r or o is satisfied.
+func (r Range) OR(o Range) Range {
+	return func(v Version) bool {
+		return r(v) || o(v)
+	}
+}
+
+// A comparator is a single operator/version pair, e.g. ">=1.2.3".
+type comparator struct {
+	op  string
+	ver Version
+}
+
+func (c comparator) satisfies(v Version) bool {
+	switch c.op {
+	case "=":
+		return v.Equals(c.ver)
+	case "!=":
+		return !v.Equals(c.ver)
+	case "<":
+		return v.LessThan(c.ver)
+	case "<=":
+		return v.LessThan(c.ver) || v.Equals(c.ver)
+	case ">":
+		return v.GreaterThan(c.ver)
+	case ">=":
+		return v.GreaterThan(c.ver) || v.Equals(c.ver)
+	}
+	return false
+}
+
+// buildGroup composes a Range from a conjunction of comparators, applying
+// the prerelease exclusion rule across the whole group.
+func buildGroup(comps []comparator) Range {
+	return func(v Version) bool {
+		if hasPrerelease(v) && !groupAllowsPrerelease(comps, v) {
+			return false
+		}
+		for _, c := range comps {
+			if !c.satisfies(v) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func orAll(ranges []Range) Range {
+	return func(v Version) bool {
+		for _, r := range ranges {
+			if r(v) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func hasPrerelease(v Version) bool {
+	return v.HasPrerelease()
+}
+
+// groupAllowsPrerelease reports whether some comparator in comps names a
+// prerelease at the same [major, minor, patch] as v.
+func groupAllowsPrerelease(comps []comparator, v Version) bool {
+	for _, c := range comps {
+		if hasPrerelease(c.ver) && c.ver.Major == v.Major && c.ver.Minor == v.Minor && c.ver.Patch == v.Patch {
+			return true
+		}
+	}
+	return false
+}
+
+// expandField parses a single space-separated range field - a plain
+// comparator, a bare/X-range version, or a ~ or ^ shorthand - into the
+// comparators it expands to.
+func expandField(field string) ([]comparator, error) {
+	switch {
+	case field == "*" || field == "x" || field == "X":
+		return nil, nil
+	case strings.HasPrefix(field, "~"):
+		return expandTilde(field[1:])
+	case strings.HasPrefix(field, "^"):
+		return expandCaret(field[1:])
+	case strings.HasPrefix(field, ">="):
+		return expandComparator(">=", field[2:])
+	case strings.HasPrefix(field, "<="):
+		return expandComparator("<=", field[2:])
+	case strings.HasPrefix(field, "!="):
+		return expandComparator("!=", field[2:])
+	case strings.HasPrefix(field, ">"):
+		return expandComparator(">", field[1:])
+	case strings.HasPrefix(field, "<"):
+		return expandComparator("<", field[1:])
+	case strings.HasPrefix(field, "="):
+		return expandComparator("=", field[1:])
+	default:
+		return expandBare(field)
+	}
+}
+
+// expandComparator expands an explicit comparator. A partial version fills
+// its missing components with zero, e.g. ">=1.2" means ">=1.2.0".
+func expandComparator(op, verStr string) ([]comparator, error) {
+	v, _, err := parsePartial(verStr)
+	if err != nil {
+		return nil, err
+	}
+	return []comparator{{op, v}}, nil
+}
+
+// expandBare expands a bare version with no comparator, which may be a
+// full version (an exact match), a partial version, or an X-range such as
+// "1.2.x" - both mean ">=1.2.0 <1.3.0".
+func expandBare(field string) ([]comparator, error) {
+	trimmed := stripWildcard(field)
+	if trimmed == "" {
+		return nil, nil
+	}
+	v, level, err := parsePartial(trimmed)
+	if err != nil {
+		return nil, err
+	}
+	return partialBounds(v, level), nil
+}
+
+// expandTilde expands a "~1.2.3" shorthand, which allows patch-level
+// changes: ">=1.2.3 <1.3.0". A partial operand widens the range to match,
+// e.g. "~1.2" and "~1" behave like the equivalent X-range.
+func expandTilde(rest string) ([]comparator, error) {
+	v, level, err := parsePartial(rest)
+	if err != nil {
+		return nil, err
+	}
+	if level == 1 {
+		return partialBounds(v, level), nil
+	}
+
+	lower := Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch, Prerelease: v.Prerelease}
+	upper := Version{Major: v.Major, Minor: v.Minor + 1}
+	return []comparator{{">=", lower}, {"<", upper}}, nil
+}
+
+// expandCaret expands a "^1.2.3" shorthand, which allows minor and patch
+// changes: ">=1.2.3 <2.0.0". Below major version 1 it locks the minor
+// version instead, and below minor version 1 (within major 0) it locks
+// the patch version, matching how 0.x releases are conventionally treated
+// as unstable. A partial operand (e.g. "^0", "^0.0") widens the range the
+// same way an X-range does, since the omitted components are wildcards
+// rather than literal zeroes.
+func expandCaret(rest string) ([]comparator, error) {
+	v, level, err := parsePartial(rest)
+	if err != nil {
+		return nil, err
+	}
+
+	lower := Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch, Prerelease: v.Prerelease}
+
+	var upper Version
+	switch {
+	case v.Major > 0:
+		upper = Version{Major: v.Major + 1}
+	case level == 1:
+		upper = Version{Major: 1}
+	case v.Minor > 0:
+		upper = Version{Minor: v.Minor + 1}
+	case level == 2:
+		upper = Version{Minor: 1}
+	default:
+		upper = Version{Minor: 0, Patch: v.Patch + 1}
+	}
+	return []comparator{{">=", lower}, {"<", upper}}, nil
+}
+
+// partialBounds turns a (possibly partial) version into the
+// ">= lower < upper" bounds implied by how many components it actually
+// supplied, or an exact match if it is fully specified.
+func partialBounds(v Version, level int) []comparator {
+	switch level {
+	case 1:
+		return []comparator{{">=", Version{Major: v.Major}}, {"<", Version{Major: v.Major + 1}}}
+	case 2:
+		return []comparator{{">=", Version{Major: v.Major, Minor: v.Minor}}, {"<", Version{Major: v.Major, Minor: v.Minor + 1}}}
+	default:
+		return []comparator{{"=", v}}
+	}
+}
+
+// stripWildcard drops a trailing X-range wildcard component ("x", "X" or
+// "*") and anything after it, leaving the partial version beneath it.
+func stripWildcard(s string) string {
+	main, prerelease := s, ""
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		main, prerelease = s[:i], s[i+1:]
+	}
+
+	var kept []string
+	for _, seg := range strings.Split(main, ".") {
+		if seg == "x" || seg == "X" || seg == "*" {
+			break
+		}
+		kept = append(kept, seg)
+	}
+
+	out := strings.Join(kept, ".")
+	if out != "" && prerelease != "" {
+		out += "-" + prerelease
+	}
+	return out
+}
+
+// parsePartial parses a (possibly partial) version string with no "v"
+// prefix, as used within range expressions. level reports how many
+// major.minor.patch components were actually supplied (1, 2 or 3) -
+// Parse itself zero-fills the rest, so this can't be recovered from the
+// parsed Version alone.
+func parsePartial(s string) (Version, int, error) {
+	if s == "" {
+		return Version{}, 0, IllegalRange
+	}
+	v, err := Parse("v" + s)
+	if err != nil {
+		return v, 0, IllegalRange
+	}
+
+	core := s
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		core = s[:i]
+	}
+	level := len(strings.Split(core, "."))
+
+	return v, level, nil
+}
@@ -0,0 +1,155 @@
+package semver
+
+import (
+	"testing"
+)
+
+func mustParse(t *testing.T, s string) Version {
+	v, err := Parse(s)
+	if err != nil {
+		t.Fatalf("unexpected error parsing %s: %v", s, err)
+	}
+	return v
+}
+
+func TestParseRangeContains(t *testing.T) {
+	tests := []struct {
+		r        string
+		v        string
+		contains bool
+	}{
+		{">=1.2.0 <1.2.9", "v1.2.0", true},
+		{">=1.2.0 <1.2.9", "v1.2.5", true},
+		{">=1.2.0 <1.2.9", "v1.2.9", false},
+		{">=1.2.0 <1.2.9", "v1.1.0", false},
+
+		{"1.2.3", "v1.2.3", true},
+		{"1.2.3", "v1.2.4", false},
+
+		{"1.2.x", "v1.2.0", true},
+		{"1.2.x", "v1.2.7", true},
+		{"1.2.x", "v1.3.0", false},
+		{"1.x", "v1.0.0", true},
+		{"1.x", "v1.5.0", true},
+		{"1.x", "v2.0.0", false},
+		{"*", "v9.9.9", true},
+
+		{"1", "v1.0.0", true},
+		{"1", "v1.5.0", true},
+		{"1", "v2.0.0", false},
+		{"1.2", "v1.2.0", true},
+		{"1.2", "v1.2.5", true},
+		{"1.2", "v1.3.0", false},
+
+		{"~1.2.0", "v1.2.0", true},
+		{"~1.2.0", "v1.3.0", false},
+		{"~1.2", "v1.2.0", true},
+		{"~1.2", "v1.3.0", false},
+		{"~1", "v1.0.0", true},
+		{"~1", "v1.7.0", true},
+		{"~1", "v2.0.0", false},
+
+		{"^1.0.0", "v1.0.0", true},
+		{"^1.0.0", "v2.0.0", false},
+		{"^0.2.0", "v0.2.0", true},
+		{"^0.2.0", "v0.3.0", false},
+		{"^0.0.3", "v0.0.3", true},
+		{"^0.0.3", "v0.0.4", false},
+		{"^0", "v0.0.0", true},
+		{"^0", "v0.5.0", true},
+		{"^0", "v1.0.0", false},
+		{"^0.0", "v0.0.7", true},
+		{"^0.0", "v0.1.0", false},
+
+		{">=1.0.0 <1.9.0 || >=3.0.0", "v3.5.0", true},
+		{">=1.0.0 <1.9.0 || >=3.0.0", "v2.0.0", false},
+
+		{"!=1.2.3", "v1.2.3", false},
+		{"!=1.2.3", "v1.2.4", true},
+	}
+
+	for _, tc := range tests {
+		r, err := ParseRange(tc.r)
+		if err != nil {
+			t.Errorf("unexpected error parsing range %q: %v", tc.r, err)
+			continue
+		}
+		v := mustParse(t, tc.v)
+		if got := r(v); got != tc.contains {
+			t.Errorf("range %q containing %s: got %v, want %v", tc.r, tc.v, got, tc.contains)
+		}
+	}
+}
+
+func TestParseRangePrereleaseExclusion(t *testing.T) {
+	tests := []struct {
+		r        string
+		v        string
+		contains bool
+	}{
+		// A prerelease can only satisfy a range that names a prerelease
+		// at the same [major, minor, patch].
+		{">=1.2.3", "v1.2.3-alpha", false},
+		{">=1.2.3-alpha", "v1.2.3-alpha", true},
+		{">=1.2.3-alpha", "v1.2.3-beta", true},
+		{">=1.2.3-alpha <1.3.0", "v1.2.4-alpha", false},
+	}
+
+	for _, tc := range tests {
+		r, err := ParseRange(tc.r)
+		if err != nil {
+			t.Errorf("unexpected error parsing range %q: %v", tc.r, err)
+			continue
+		}
+		v := mustParse(t, tc.v)
+		if got := r(v); got != tc.contains {
+			t.Errorf("range %q containing %s: got %v, want %v", tc.r, tc.v, got, tc.contains)
+		}
+	}
+}
+
+func TestRangeANDOR(t *testing.T) {
+	a := MustParseRange(">=1.0.0")
+	b := MustParseRange("<1.9.0")
+	c := MustParseRange(">=3.0.0")
+
+	and := a.AND(b)
+	if !and(mustParse(t, "v1.5.0")) {
+		t.Errorf("expected v1.5.0 to satisfy the AND of %v", []string{">=1.0.0", "<1.9.0"})
+	}
+	if and(mustParse(t, "v2.0.0")) {
+		t.Errorf("expected v2.0.0 not to satisfy the AND of %v", []string{">=1.0.0", "<1.9.0"})
+	}
+
+	or := and.OR(c)
+	if !or(mustParse(t, "v3.5.0")) {
+		t.Errorf("expected v3.5.0 to satisfy the OR")
+	}
+	if or(mustParse(t, "v2.0.0")) {
+		t.Errorf("expected v2.0.0 not to satisfy the OR")
+	}
+}
+
+func TestParseRangeErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"   ",
+		">=1.2.0 || ",
+		">=lol",
+	}
+
+	for _, tc := range tests {
+		if _, err := ParseRange(tc); err == nil {
+			t.Errorf("expected an error parsing range %q", tc)
+		}
+	}
+}
+
+func TestMustParseRangePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected MustParseRange to panic on an illegal range")
+		}
+	}()
+	MustParseRange("")
+}
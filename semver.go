@@ -22,8 +22,8 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
-	"sort"
 	"strconv"
+	"strings"
 )
 
 // A Version is a parsed semver version string.
@@ -33,44 +33,105 @@ type Version struct {
 	Major      int
 	Minor      int
 	Patch      int
-	Prerelease string
+	Prerelease []PRIdentifier
+	Build      []string
+}
+
+// A PRIdentifier is a single dot-separated prerelease identifier, e.g. the
+// "beta" or "2" in "1.0.0-beta.2". Identifiers consisting entirely of
+// digits are compared numerically; all others are compared lexically in
+// ASCII sort order.
+type PRIdentifier struct {
+	IsNumeric bool
+	Num       int
+	Str       string
+}
+
+// String returns the identifier's textual representation.
+func (p PRIdentifier) String() string {
+	if p.IsNumeric {
+		return strconv.Itoa(p.Num)
+	}
+	return p.Str
+}
+
+// compare returns -1, 0 or 1 as p is less than, equal to, or greater than
+// o, per SemVer 2.0.0's prerelease precedence rules: numeric identifiers
+// always have lower precedence than alphanumeric ones.
+func (p PRIdentifier) compare(o PRIdentifier) int {
+	if p.IsNumeric && o.IsNumeric {
+		switch {
+		case p.Num < o.Num:
+			return -1
+		case p.Num > o.Num:
+			return 1
+		default:
+			return 0
+		}
+	}
+	if p.IsNumeric != o.IsNumeric {
+		if p.IsNumeric {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(p.Str, o.Str)
+}
+
+// HasPrerelease reports whether v has a prerelease component.
+func (v Version) HasPrerelease() bool {
+	return len(v.Prerelease) > 0
+}
+
+// PrereleaseString returns the prerelease component joined back into its
+// dot-separated textual form, e.g. "beta.2".
+func (v Version) PrereleaseString() string {
+	parts := make([]string, len(v.Prerelease))
+	for i, p := range v.Prerelease {
+		parts[i] = p.String()
+	}
+	return strings.Join(parts, ".")
+}
+
+// BuildString returns the build metadata component joined back into its
+// dot-separated textual form, e.g. "build.5".
+func (v Version) BuildString() string {
+	return strings.Join(v.Build, ".")
 }
 
 // Returns the standard string representation of the Version value.
 //
 // For example, given this Version value:
 //
-//  Version{Major: 1, Minor: 2, Patch: 3, Prerelease: "beta1"}
+//  Version{Major: 1, Minor: 2, Patch: 3, Prerelease: []PRIdentifier{{Str: "beta1"}}}
 //
 // The following string is produced:
 //
 //  v1.2.3-beta1
 func (v Version) String() string {
-	if len(v.Prerelease) > 0 {
-		return fmt.Sprintf("v%d.%d.%d-%s", v.Major, v.Minor, v.Patch, v.Prerelease)
-	} else {
-		return fmt.Sprintf("v%d.%d.%d", v.Major, v.Minor, v.Patch)
+	s := fmt.Sprintf("v%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.HasPrerelease() {
+		s += "-" + v.PrereleaseString()
+	}
+	if len(v.Build) > 0 {
+		s += "+" + v.BuildString()
 	}
+	return s
 }
 
 // Returns true if v is a higher version than o.
+//
+// Precedence follows SemVer 2.0.0: major, minor and patch are compared
+// numerically; a version with a prerelease has lower precedence than one
+// without; otherwise prerelease identifiers are compared left-to-right,
+// and a prerelease with fewer identifiers has lower precedence than one
+// with more, when all preceding identifiers are equal. Build metadata is
+// ignored.
 func (v Version) GreaterThan(o Version) bool {
-	if v.Major > o.Major {
-		return true
-	} else if v.Minor > o.Minor {
-		return true
-	} else if v.Patch > o.Patch {
-		return true
-	} else if v.Prerelease == o.Prerelease {
-		return false
-	}
-
-	sl := []string{v.Prerelease, o.Prerelease}
-	sort.Strings(sl)
-	if sl[0] == v.Prerelease {
-		return false
+	if c := compareCore(v, o); c != 0 {
+		return c > 0
 	}
-	return true
+	return comparePrerelease(v.Prerelease, o.Prerelease) > 0
 }
 
 // Returns true if v is a lesser version than o.
@@ -78,9 +139,53 @@ func (v Version) LessThan(o Version) bool {
 	return !v.Equals(o) && !v.GreaterThan(o)
 }
 
-// Returns true if v and o are the same version.
+// Returns true if v and o are the same version. Build metadata is ignored.
 func (v Version) Equals(o Version) bool {
-	return v.Major == o.Major && v.Minor == o.Minor && v.Patch == o.Patch && v.Prerelease == o.Prerelease
+	return compareCore(v, o) == 0 && comparePrerelease(v.Prerelease, o.Prerelease) == 0
+}
+
+// compareCore compares the numeric major.minor.patch triple, returning
+// -1, 0 or 1 as v is less than, equal to, or greater than o.
+func compareCore(v, o Version) int {
+	switch {
+	case v.Major != o.Major:
+		return sign(v.Major - o.Major)
+	case v.Minor != o.Minor:
+		return sign(v.Minor - o.Minor)
+	default:
+		return sign(v.Patch - o.Patch)
+	}
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// comparePrerelease compares two prerelease identifier lists, returning
+// -1, 0 or 1 as a is less than, equal to, or greater than b.
+func comparePrerelease(a, b []PRIdentifier) int {
+	if len(a) == 0 && len(b) == 0 {
+		return 0
+	}
+	if len(a) == 0 {
+		return 1
+	}
+	if len(b) == 0 {
+		return -1
+	}
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if c := a[i].compare(b[i]); c != 0 {
+			return c
+		}
+	}
+	return sign(len(a) - len(b))
 }
 
 // States for parsing state machine.
@@ -117,6 +222,11 @@ func Parse(s string) (Version, error) {
 	if len(s) == 0 {
 		return v, EmptyVersion
 	}
+	if s[len(s)-1] == '-' || s[len(s)-1] == '+' {
+		// A trailing delimiter with nothing after it (e.g. "v1.2.3-")
+		// would otherwise slip past the state machine unnoticed.
+		return v, IllegalVersion
+	}
 
 	// Loop until we find an error or we've finished parsing the string
 
@@ -151,7 +261,23 @@ func Parse(s string) (Version, error) {
 			v.Patch = patch
 			state = foundPatch
 		case foundPatch:
-			v.Prerelease = s[pos:]
+			rest := s[pos:]
+			if s[pos-1] == '+' {
+				if v.Build, err = parseBuild(rest); err != nil {
+					return v, err
+				}
+			} else {
+				prereleasePart := rest
+				if i := strings.IndexByte(rest, '+'); i >= 0 {
+					prereleasePart = rest[:i]
+					if v.Build, err = parseBuild(rest[i+1:]); err != nil {
+						return v, err
+					}
+				}
+				if v.Prerelease, err = parsePrerelease(prereleasePart); err != nil {
+					return v, err
+				}
+			}
 			pos = len(s)
 			state = foundPrerelease
 		}
@@ -168,12 +294,12 @@ func Parse(s string) (Version, error) {
 // Read the next version number from this cursor in the string.
 // buf should be an empty bytes.Buffer. The buffer will be automatically reset.
 //
-// Reads until a period, hyphen or the end of the string.
+// Reads until a period, hyphen, plus or the end of the string.
 //
 // Returns the version number, the new cursor point and any error.
 func readNextNum(s string, curs int, buf *bytes.Buffer) (int, int, error) {
 	defer buf.Reset()
-	for ; curs < len(s) && s[curs] != '.' && s[curs] != '-'; curs += 1 {
+	for ; curs < len(s) && s[curs] != '.' && s[curs] != '-' && s[curs] != '+'; curs += 1 {
 		buf.WriteByte(s[curs])
 	}
 	i, err := strconv.Atoi(buf.String())
@@ -182,3 +308,72 @@ func readNextNum(s string, curs int, buf *bytes.Buffer) (int, int, error) {
 	}
 	return i, curs + 1, nil
 }
+
+// parsePrerelease splits a prerelease component on "." and validates each
+// identifier, rejecting empty identifiers, leading zeros on numeric
+// identifiers, and characters outside [0-9A-Za-z-].
+func parsePrerelease(s string) ([]PRIdentifier, error) {
+	parts, err := splitIdentifiers(s)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]PRIdentifier, len(parts))
+	for i, p := range parts {
+		if isNumericIdentifier(p) {
+			if len(p) > 1 && p[0] == '0' {
+				return nil, IllegalVersion
+			}
+			n, err := strconv.Atoi(p)
+			if err != nil {
+				return nil, IllegalVersion
+			}
+			ids[i] = PRIdentifier{IsNumeric: true, Num: n}
+		} else {
+			ids[i] = PRIdentifier{Str: p}
+		}
+	}
+	return ids, nil
+}
+
+// parseBuild splits a build metadata component on "." and validates each
+// identifier, rejecting empty identifiers and characters outside
+// [0-9A-Za-z-]. Unlike prerelease identifiers, leading zeros are allowed.
+func parseBuild(s string) ([]string, error) {
+	return splitIdentifiers(s)
+}
+
+// splitIdentifiers splits a dot-separated component into its identifiers,
+// rejecting an empty component, empty identifiers and characters outside
+// [0-9A-Za-z-].
+func splitIdentifiers(s string) ([]string, error) {
+	if s == "" {
+		return nil, IllegalVersion
+	}
+	parts := strings.Split(s, ".")
+	for _, p := range parts {
+		if p == "" || !isIdentifierChars(p) {
+			return nil, IllegalVersion
+		}
+	}
+	return parts, nil
+}
+
+func isIdentifierChars(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+func isNumericIdentifier(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -4,15 +4,30 @@ import (
 	"testing"
 )
 
+// ver builds a Version for test tables, parsing pre as a dot-separated
+// prerelease component ("" for none).
+func ver(major, minor, patch int, pre string) Version {
+	v := Version{Major: major, Minor: minor, Patch: patch}
+	if pre != "" {
+		ids, err := parsePrerelease(pre)
+		if err != nil {
+			panic(err)
+		}
+		v.Prerelease = ids
+	}
+	return v
+}
+
 // Test string representation of versions
 func TestString(t *testing.T) {
 	tests := []struct {
 		v Version
 		s string
 	}{
-		{Version{1, 2, 3, ""}, "v1.2.3"},
-		{Version{1, 2, 3, "beta"}, "v1.2.3-beta"},
-		{Version{0, 2, 3, "beta"}, "v0.2.3-beta"},
+		{ver(1, 2, 3, ""), "v1.2.3"},
+		{ver(1, 2, 3, "beta"), "v1.2.3-beta"},
+		{ver(0, 2, 3, "beta"), "v0.2.3-beta"},
+		{ver(1, 2, 3, "beta.2"), "v1.2.3-beta.2"},
 	}
 
 	for _, tc := range tests {
@@ -23,20 +38,30 @@ func TestString(t *testing.T) {
 	}
 }
 
+func TestStringWithBuild(t *testing.T) {
+	v, err := Parse("v1.2.3-beta.2+build.5")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := v.String(), "v1.2.3-beta.2+build.5"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
 func TestEqual(t *testing.T) {
 	tests := []struct {
 		a     Version
 		b     Version
 		equal bool
 	}{
-		{Version{1, 2, 3, ""}, Version{1, 2, 3, ""}, true},
-		{Version{1, 2, 3, "beta"}, Version{1, 2, 3, "beta"}, true},
-		{Version{0, 0, 1, "foo"}, Version{0, 0, 1, "foo"}, true},
-
-		{Version{1, 2, 3, "beta"}, Version{0, 2, 3, "beta"}, false},
-		{Version{1, 2, 3, "beta"}, Version{1, 0, 3, "beta"}, false},
-		{Version{1, 2, 3, "beta"}, Version{1, 2, 0, "beta"}, false},
-		{Version{1, 2, 3, "beta"}, Version{1, 2, 3, ""}, false},
+		{ver(1, 2, 3, ""), ver(1, 2, 3, ""), true},
+		{ver(1, 2, 3, "beta"), ver(1, 2, 3, "beta"), true},
+		{ver(0, 0, 1, "foo"), ver(0, 0, 1, "foo"), true},
+
+		{ver(1, 2, 3, "beta"), ver(0, 2, 3, "beta"), false},
+		{ver(1, 2, 3, "beta"), ver(1, 0, 3, "beta"), false},
+		{ver(1, 2, 3, "beta"), ver(1, 2, 0, "beta"), false},
+		{ver(1, 2, 3, "beta"), ver(1, 2, 3, ""), false},
 	}
 
 	for _, tc := range tests {
@@ -59,21 +84,35 @@ func TestGreaterThan(t *testing.T) {
 	}{
 
 		// equal
-		{Version{1, 2, 3, ""}, Version{1, 2, 3, ""}, false},
-		{Version{1, 2, 3, "beta"}, Version{1, 2, 3, "beta"}, false},
+		{ver(1, 2, 3, ""), ver(1, 2, 3, ""), false},
+		{ver(1, 2, 3, "beta"), ver(1, 2, 3, "beta"), false},
 
 		// greater
-		{Version{2, 2, 3, "beta"}, Version{1, 2, 3, "beta"}, true},
-		{Version{1, 3, 3, "beta"}, Version{1, 2, 3, "beta"}, true},
-		{Version{1, 2, 4, "beta"}, Version{1, 2, 3, "beta"}, true},
-		{Version{1, 2, 3, "c"}, Version{1, 2, 3, "beta"}, true},
-		{Version{1, 2, 3, "z"}, Version{1, 2, 3, "foo"}, true},
-		{Version{Major: 4}, Version{3, 6, 1, ""}, true},
+		{ver(2, 2, 3, "beta"), ver(1, 2, 3, "beta"), true},
+		{ver(1, 3, 3, "beta"), ver(1, 2, 3, "beta"), true},
+		{ver(1, 2, 4, "beta"), ver(1, 2, 3, "beta"), true},
+		{ver(1, 2, 3, "c"), ver(1, 2, 3, "beta"), true},
+		{ver(1, 2, 3, "z"), ver(1, 2, 3, "foo"), true},
+		{Version{Major: 4}, ver(3, 6, 1, ""), true},
 
 		// lesser
-		{Version{0, 0, 1, "foo"}, Version{0, 0, 1, "z"}, false},
-		{Version{0, 0, 1, "foo"}, Version{0, 0, 2, "foo"}, false},
-		{Version{3, 6, 1, ""}, Version{Major: 4}, false},
+		{ver(0, 0, 1, "foo"), ver(0, 0, 1, "z"), false},
+		{ver(0, 0, 1, "foo"), ver(0, 0, 2, "foo"), false},
+		{ver(3, 6, 1, ""), Version{Major: 4}, false},
+
+		// a version with a prerelease has lower precedence than one
+		// without, even when major.minor.patch match
+		{ver(1, 0, 0, ""), ver(1, 0, 0, "rc.1"), true},
+		{ver(1, 0, 0, "rc.1"), ver(1, 0, 0, ""), false},
+
+		// a shorter set of identifiers has lower precedence than a
+		// longer one, when all preceding identifiers are equal
+		{ver(1, 0, 0, "alpha.1"), ver(1, 0, 0, "alpha"), true},
+
+		// numeric identifiers are compared numerically and always
+		// rank below alphanumeric ones at the same position
+		{ver(1, 0, 0, "alpha.2"), ver(1, 0, 0, "alpha.10"), false},
+		{ver(1, 0, 0, "alpha.beta"), ver(1, 0, 0, "alpha.10"), true},
 	}
 
 	for _, tc := range tests {
@@ -96,21 +135,21 @@ func TestLessThan(t *testing.T) {
 	}{
 
 		// equal
-		{Version{1, 2, 3, ""}, Version{1, 2, 3, ""}, false},
-		{Version{1, 2, 3, "beta"}, Version{1, 2, 3, "beta"}, false},
+		{ver(1, 2, 3, ""), ver(1, 2, 3, ""), false},
+		{ver(1, 2, 3, "beta"), ver(1, 2, 3, "beta"), false},
 
 		// greater
-		{Version{2, 2, 3, "beta"}, Version{1, 2, 3, "beta"}, false},
-		{Version{1, 3, 3, "beta"}, Version{1, 2, 3, "beta"}, false},
-		{Version{1, 2, 4, "beta"}, Version{1, 2, 3, "beta"}, false},
-		{Version{1, 2, 3, "c"}, Version{1, 2, 3, "beta"}, false},
-		{Version{1, 2, 3, "z"}, Version{1, 2, 3, "foo"}, false},
-		{Version{Major: 4}, Version{3, 6, 1, ""}, false},
+		{ver(2, 2, 3, "beta"), ver(1, 2, 3, "beta"), false},
+		{ver(1, 3, 3, "beta"), ver(1, 2, 3, "beta"), false},
+		{ver(1, 2, 4, "beta"), ver(1, 2, 3, "beta"), false},
+		{ver(1, 2, 3, "c"), ver(1, 2, 3, "beta"), false},
+		{ver(1, 2, 3, "z"), ver(1, 2, 3, "foo"), false},
+		{Version{Major: 4}, ver(3, 6, 1, ""), false},
 
 		// lesser
-		{Version{0, 0, 1, "foo"}, Version{0, 0, 1, "z"}, true},
-		{Version{0, 0, 1, "foo"}, Version{0, 0, 2, "foo"}, true},
-		{Version{3, 6, 1, ""}, Version{Major: 4}, true},
+		{ver(0, 0, 1, "foo"), ver(0, 0, 1, "z"), true},
+		{ver(0, 0, 1, "foo"), ver(0, 0, 2, "foo"), true},
+		{ver(3, 6, 1, ""), Version{Major: 4}, true},
 	}
 
 	for _, tc := range tests {
@@ -125,6 +164,39 @@ func TestLessThan(t *testing.T) {
 	}
 }
 
+// TestCanonicalOrdering exercises the SemVer 2.0.0 spec's own example of
+// prerelease precedence ordering.
+func TestCanonicalOrdering(t *testing.T) {
+	order := []string{
+		"v1.0.0-alpha",
+		"v1.0.0-alpha.1",
+		"v1.0.0-alpha.beta",
+		"v1.0.0-beta",
+		"v1.0.0-beta.2",
+		"v1.0.0-beta.11",
+		"v1.0.0-rc.1",
+		"v1.0.0",
+	}
+
+	versions := make([]Version, len(order))
+	for i, s := range order {
+		v, err := Parse(s)
+		if err != nil {
+			t.Fatalf("unexpected error parsing %s: %v", s, err)
+		}
+		versions[i] = v
+	}
+
+	for i := 0; i < len(versions)-1; i++ {
+		if !versions[i+1].GreaterThan(versions[i]) {
+			t.Errorf("expected %s to be greater than %s", order[i+1], order[i])
+		}
+		if !versions[i].LessThan(versions[i+1]) {
+			t.Errorf("expected %s to be less than %s", order[i], order[i+1])
+		}
+	}
+}
+
 func TestParse(t *testing.T) {
 	tests := []struct {
 		input string
@@ -136,6 +208,8 @@ func TestParse(t *testing.T) {
 		{"v1.2.3", true},
 		{"v1.2.3-rc.2", true},
 		{"v2.0.0-beta", true},
+		{"v1.2.3+build.5", true},
+		{"v1.2.3-beta.2+build.5", true},
 
 		{"", false},
 		{"v", false},
@@ -155,6 +229,14 @@ func TestParse(t *testing.T) {
 		{"1.2.3", false},
 		{"1.2.3haha", false},
 		{"v-", false},
+
+		// prerelease and build metadata validation
+		{"v1.2.3-", false},
+		{"v1.2.3-01", false},
+		{"v1.2.3-alpha..1", false},
+		{"v1.2.3-alpha_beta", false},
+		{"v1.2.3+", false},
+		{"v1.2.3+build_5", false},
 	}
 
 	for _, tc := range tests {
@@ -0,0 +1,65 @@
+package semver
+
+import "sort"
+
+// Versions implements sort.Interface for a slice of Version values, using
+// their spec-correct precedence.
+type Versions []Version
+
+func (vs Versions) Len() int           { return len(vs) }
+func (vs Versions) Less(i, j int) bool { return vs[i].LessThan(vs[j]) }
+func (vs Versions) Swap(i, j int)      { vs[i], vs[j] = vs[j], vs[i] }
+
+// Sort sorts versions in ascending order.
+func Sort(versions []Version) {
+	sort.Sort(Versions(versions))
+}
+
+// SortStable stably sorts versions in ascending order.
+func SortStable(versions []Version) {
+	sort.Stable(Versions(versions))
+}
+
+// Latest returns the highest version in versions. Prerelease versions are
+// only considered if no non-prerelease version is present. The second
+// return value is false if versions is empty.
+func Latest(versions []Version) (Version, bool) {
+	if len(versions) == 0 {
+		return Version{}, false
+	}
+
+	var (
+		best        Version
+		bestPre     Version
+		haveBest    bool
+		haveBestPre bool
+	)
+
+	for _, v := range versions {
+		if v.HasPrerelease() {
+			if !haveBestPre || v.GreaterThan(bestPre) {
+				bestPre = v
+				haveBestPre = true
+			}
+			continue
+		}
+		if !haveBest || v.GreaterThan(best) {
+			best = v
+			haveBest = true
+		}
+	}
+
+	if haveBest {
+		return best, true
+	}
+	return bestPre, true
+}
+
+// Search returns the index at which target would be inserted into
+// versions to keep it sorted, mirroring sort.Search. versions must
+// already be sorted in ascending order.
+func Search(versions []Version, target Version) int {
+	return sort.Search(len(versions), func(i int) bool {
+		return !versions[i].LessThan(target)
+	})
+}
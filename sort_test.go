@@ -0,0 +1,91 @@
+package semver
+
+import (
+	"sort"
+	"testing"
+)
+
+func parseAll(t *testing.T, ss ...string) []Version {
+	t.Helper()
+	vs := make([]Version, len(ss))
+	for i, s := range ss {
+		v, err := Parse(s)
+		if err != nil {
+			t.Fatalf("unexpected error parsing %s: %v", s, err)
+		}
+		vs[i] = v
+	}
+	return vs
+}
+
+func TestSort(t *testing.T) {
+	versions := parseAll(t, "v2.0.0", "v1.0.0-beta", "v1.0.0", "v1.2.0")
+	Sort(versions)
+
+	want := []string{"v1.0.0-beta", "v1.0.0", "v1.2.0", "v2.0.0"}
+	for i, v := range versions {
+		if v.String() != want[i] {
+			t.Errorf("position %d: got %s, want %s", i, v, want[i])
+		}
+	}
+	if !sort.IsSorted(Versions(versions)) {
+		t.Errorf("expected versions to be sorted")
+	}
+}
+
+func TestSortStable(t *testing.T) {
+	versions := parseAll(t, "v1.0.0", "v2.0.0", "v1.0.0")
+	SortStable(versions)
+	if !sort.IsSorted(Versions(versions)) {
+		t.Errorf("expected versions to be sorted")
+	}
+}
+
+func TestLatest(t *testing.T) {
+	tests := []struct {
+		versions []string
+		want     string
+	}{
+		{[]string{"v1.0.0", "v2.0.0", "v1.5.0"}, "v2.0.0"},
+		{[]string{"v1.0.0-alpha", "v1.0.0-beta"}, "v1.0.0-beta"},
+		{[]string{"v1.0.0-beta", "v1.0.0"}, "v1.0.0"},
+	}
+
+	for _, tc := range tests {
+		got, ok := Latest(parseAll(t, tc.versions...))
+		if !ok {
+			t.Errorf("Latest(%v) returned ok=false", tc.versions)
+			continue
+		}
+		if got.String() != tc.want {
+			t.Errorf("Latest(%v) = %s, want %s", tc.versions, got, tc.want)
+		}
+	}
+}
+
+func TestLatestEmpty(t *testing.T) {
+	if _, ok := Latest(nil); ok {
+		t.Errorf("expected Latest(nil) to return ok=false")
+	}
+}
+
+func TestSearch(t *testing.T) {
+	versions := parseAll(t, "v1.0.0", "v1.2.0", "v1.5.0", "v2.0.0")
+
+	tests := []struct {
+		target string
+		want   int
+	}{
+		{"v1.2.0", 1},
+		{"v1.3.0", 2},
+		{"v0.1.0", 0},
+		{"v3.0.0", 4},
+	}
+
+	for _, tc := range tests {
+		target := parseAll(t, tc.target)[0]
+		if got := Search(versions, target); got != tc.want {
+			t.Errorf("Search(versions, %s) = %d, want %d", tc.target, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package semver
+
+import "strings"
+
+// ParseTolerant parses a version string the same way as Parse, but first
+// normalises common deviations seen in real-world version strings: a
+// missing "v" prefix, surrounding whitespace, an upper-case "V" prefix,
+// leading zeros on numeric components, and a missing minor or patch
+// component (filled with "0" rather than left partial).
+//
+// This is useful when comparing client-supplied version strings (e.g. an
+// API version header) where format discipline can't be relied upon.
+func ParseTolerant(s string) (Version, error) {
+	s = strings.TrimSpace(s)
+
+	if strings.HasPrefix(s, "V") {
+		s = "v" + s[1:]
+	}
+	if !strings.HasPrefix(s, "v") {
+		s = "v" + s
+	}
+
+	s = stripLeadingZeros(s)
+	s = fillMissingComponents(s)
+
+	return Parse(s)
+}
+
+// stripLeadingZeros removes leading zeros from the numeric major, minor
+// and patch components, e.g. "v01.02.03" becomes "v1.2.3". It leaves the
+// prerelease and build components, which have their own leading-zero
+// rules, untouched.
+func stripLeadingZeros(s string) string {
+	core, tail := splitVersionCore(s)
+
+	segs := strings.SplitN(core, ".", 3)
+	for i, seg := range segs {
+		segs[i] = stripLeadingZerosFromDigits(seg)
+	}
+
+	return "v" + strings.Join(segs, ".") + tail
+}
+
+func stripLeadingZerosFromDigits(s string) string {
+	i := 0
+	for i < len(s)-1 && s[i] == '0' {
+		i++
+	}
+	return s[i:]
+}
+
+// fillMissingComponents fills in a missing minor or patch component with
+// "0", e.g. "v1" becomes "v1.0.0" and "v1.2" becomes "v1.2.0".
+func fillMissingComponents(s string) string {
+	core, tail := splitVersionCore(s)
+
+	segs := strings.Split(core, ".")
+	for len(segs) < 3 {
+		segs = append(segs, "0")
+	}
+
+	return "v" + strings.Join(segs, ".") + tail
+}
+
+// splitVersionCore splits off the "vMAJOR.MINOR.PATCH" core of a version
+// string from everything after it (a prerelease and/or build tail).
+func splitVersionCore(s string) (core, tail string) {
+	end := len(s)
+	for i := 1; i < len(s); i++ {
+		if s[i] == '-' || s[i] == '+' {
+			end = i
+			break
+		}
+	}
+	return s[1:end], s[end:]
+}
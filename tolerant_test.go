@@ -0,0 +1,55 @@
+package semver
+
+import (
+	"testing"
+)
+
+func TestParseTolerant(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"v1.2.3", "v1.2.3"},
+		{"1.2.3", "v1.2.3"},
+		{"  1.2.3  ", "v1.2.3"},
+		{"V1.2.3", "v1.2.3"},
+		{"v01.02.03", "v1.2.3"},
+		{"1", "v1.0.0"},
+		{"v1", "v1.0.0"},
+		{"1.2", "v1.2.0"},
+		{"v1.2.3-beta.1", "v1.2.3-beta.1"},
+		{"1.2.3+build.5", "v1.2.3+build.5"},
+		{"01", "v1.0.0"},
+	}
+
+	for _, tc := range tests {
+		v, err := ParseTolerant(tc.input)
+		if err != nil {
+			t.Errorf("unexpected error parsing %q: %v", tc.input, err)
+			continue
+		}
+		if got := v.String(); got != tc.want {
+			t.Errorf("ParseTolerant(%q) = %s, want %s", tc.input, got, tc.want)
+		}
+	}
+}
+
+func TestParseTolerantInvalid(t *testing.T) {
+	tests := []string{
+		"",
+		"lol",
+		"v1.2.haha",
+	}
+
+	for _, tc := range tests {
+		if _, err := ParseTolerant(tc); err == nil {
+			t.Errorf("expected an error parsing %q", tc)
+		}
+	}
+}
+
+func TestParseUnaffectedByParseTolerant(t *testing.T) {
+	if _, err := Parse("1.2.3"); err == nil {
+		t.Errorf("expected Parse to still reject a missing v prefix")
+	}
+}